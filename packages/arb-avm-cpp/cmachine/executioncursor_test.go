@@ -17,10 +17,13 @@
 package cmachine
 
 import (
-	"github.com/offchainlabs/arbitrum/packages/arb-util/common"
 	"math/big"
 	"os"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/offchainlabs/arbitrum/packages/arb-util/common"
 )
 
 func TestExecutionCursor(t *testing.T) {
@@ -80,3 +83,56 @@ func TestExecutionCursor(t *testing.T) {
 		logger.Error().Msg("log acc is zero after execution")
 	}
 }
+
+func TestGenerateLogAccumulator(t *testing.T) {
+	dbPath := "dbPathLogAcc"
+
+	if err := os.RemoveAll(dbPath); err != nil {
+		logger.Error().Stack().Err(err).Send()
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(dbPath); err != nil {
+			logger.Error().Stack().Err(err).Send()
+			t.Fatal(err)
+		}
+	}()
+
+	arbStorage, err := NewArbStorage(dbPath)
+	if err != nil {
+		logger.Error().Stack().Err(err).Send()
+		t.Fatal(err)
+	}
+
+	if err := arbStorage.Initialize(codeFile); err != nil {
+		t.Fatal(err)
+	}
+	defer arbStorage.CloseArbStorage()
+
+	arbCore := arbStorage.GetArbCore()
+	startIndex := big.NewInt(0)
+	count := big.NewInt(10000)
+
+	logs, err := arbCore.GetLogs(startIndex, count)
+	if err != nil {
+		logger.Error().Stack().Err(err).Send()
+		t.Fatal(err)
+	}
+
+	// Independently compute the chained accumulator from the raw logs so
+	// this doesn't just check GenerateLogAccumulator against itself.
+	expectedAcc := common.Hash{}
+	for _, log := range logs {
+		logHash := log.Hash()
+		expectedAcc = common.NewHashFromEth(crypto.Keccak256Hash(expectedAcc.Bytes(), logHash.Bytes()))
+	}
+
+	acc, err := GenerateLogAccumulator(arbCore, startIndex, count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !acc.Equals(expectedAcc) {
+		t.Error("log accumulator doesn't match independently computed hash chain")
+	}
+}
@@ -0,0 +1,52 @@
+/*
+* Copyright 2019-2020, Offchain Labs, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package cmachine
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/offchainlabs/arbitrum/packages/arb-util/common"
+)
+
+// GenerateLogAccumulator replays the count logs starting at startIndex and
+// returns the resulting chained log accumulator, the same value the rollup
+// contract expects a validator to supply when confirming a node as valid.
+func GenerateLogAccumulator(arbCore ArbCore, startIndex *big.Int, count *big.Int) (common.Hash, error) {
+	cursor, err := arbCore.GetExecutionCursor(startIndex)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "error getting execution cursor")
+	}
+	if count.Sign() > 0 {
+		if err := arbCore.AdvanceExecutionCursor(cursor, count, true); err != nil {
+			return common.Hash{}, errors.Wrap(err, "error advancing execution cursor")
+		}
+	}
+	return cursor.LogAcc(), nil
+}
+
+// GenerateSendsData collects the raw outbound messages produced in the range
+// [startIndex, startIndex+count), in the order the rollup contract expects
+// them when confirming a node.
+func GenerateSendsData(arbCore ArbCore, startIndex *big.Int, count *big.Int) ([][]byte, error) {
+	sends, err := arbCore.GetSends(startIndex, count)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting sends")
+	}
+	return sends, nil
+}
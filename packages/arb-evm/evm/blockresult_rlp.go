@@ -0,0 +1,201 @@
+/*
+ * Copyright 2019-2020, Offchain Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evm
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// previousHeightPresent is set in rlpBlockInfo.Header when BlockInfo's
+// optional PreviousHeight was non-nil at encode time. big.Int.Bytes()
+// already collapses a zero value to an empty string, the same encoding
+// rlp gives a nil *big.Int, so a zero PreviousHeight and an absent one
+// would otherwise be indistinguishable on decode.
+const previousHeightPresent = 1 << 0
+
+// bigIntToRLP converts val to the byte string rlp uses to encode integers,
+// treating a nil val the same way go-ethereum's rlp package treats a nil
+// *big.Int: as an empty string.
+func bigIntToRLP(val *big.Int) []byte {
+	if val == nil {
+		return nil
+	}
+	return val.Bytes()
+}
+
+// rlpToBigInt is the inverse of bigIntToRLP for non-nil values. An empty
+// string decodes as zero, so a nil input to bigIntToRLP comes back as zero.
+func rlpToBigInt(data []byte) *big.Int {
+	if len(data) == 0 {
+		return big.NewInt(0)
+	}
+	return new(big.Int).SetBytes(data)
+}
+
+type rlpOutputStatistics struct {
+	GasUsed      []byte
+	TxCount      []byte
+	EVMLogCount  []byte
+	AVMLogCount  []byte
+	AVMSendCount []byte
+}
+
+func toRLPOutputStatistics(o *OutputStatistics) rlpOutputStatistics {
+	return rlpOutputStatistics{
+		GasUsed:      bigIntToRLP(o.GasUsed),
+		TxCount:      bigIntToRLP(o.TxCount),
+		EVMLogCount:  bigIntToRLP(o.EVMLogCount),
+		AVMLogCount:  bigIntToRLP(o.AVMLogCount),
+		AVMSendCount: bigIntToRLP(o.AVMSendCount),
+	}
+}
+
+func fromRLPOutputStatistics(dec rlpOutputStatistics) *OutputStatistics {
+	return &OutputStatistics{
+		GasUsed:      rlpToBigInt(dec.GasUsed),
+		TxCount:      rlpToBigInt(dec.TxCount),
+		EVMLogCount:  rlpToBigInt(dec.EVMLogCount),
+		AVMLogCount:  rlpToBigInt(dec.AVMLogCount),
+		AVMSendCount: rlpToBigInt(dec.AVMSendCount),
+	}
+}
+
+// EncodeRLP implements rlp.Encoder.
+func (o *OutputStatistics) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, toRLPOutputStatistics(o))
+}
+
+// DecodeRLP implements rlp.Decoder.
+func (o *OutputStatistics) DecodeRLP(s *rlp.Stream) error {
+	var dec rlpOutputStatistics
+	if err := s.Decode(&dec); err != nil {
+		return err
+	}
+	*o = *fromRLPOutputStatistics(dec)
+	return nil
+}
+
+type rlpGasAccountingSummary struct {
+	CurrentPrice          []byte
+	GasPool               []byte
+	Shortfall             []byte
+	TotalPaidToValidators []byte
+	PayoutAddress         []byte
+}
+
+func toRLPGasAccountingSummary(g *GasAccountingSummary) rlpGasAccountingSummary {
+	return rlpGasAccountingSummary{
+		CurrentPrice:          bigIntToRLP(g.CurrentPrice),
+		GasPool:               bigIntToRLP(g.GasPool),
+		Shortfall:             bigIntToRLP(g.Shortfall),
+		TotalPaidToValidators: bigIntToRLP(g.TotalPaidToValidators),
+		PayoutAddress:         bigIntToRLP(g.PayoutAddress),
+	}
+}
+
+func fromRLPGasAccountingSummary(dec rlpGasAccountingSummary) *GasAccountingSummary {
+	return &GasAccountingSummary{
+		CurrentPrice:          rlpToBigInt(dec.CurrentPrice),
+		GasPool:               rlpToBigInt(dec.GasPool),
+		Shortfall:             rlpToBigInt(dec.Shortfall),
+		TotalPaidToValidators: rlpToBigInt(dec.TotalPaidToValidators),
+		PayoutAddress:         rlpToBigInt(dec.PayoutAddress),
+	}
+}
+
+// EncodeRLP implements rlp.Encoder.
+func (g *GasAccountingSummary) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, toRLPGasAccountingSummary(g))
+}
+
+// DecodeRLP implements rlp.Decoder.
+func (g *GasAccountingSummary) DecodeRLP(s *rlp.Stream) error {
+	var dec rlpGasAccountingSummary
+	if err := s.Decode(&dec); err != nil {
+		return err
+	}
+	*g = *fromRLPGasAccountingSummary(dec)
+	return nil
+}
+
+type rlpBlockInfo struct {
+	BlockNum       []byte
+	Timestamp      []byte
+	GasLimit       []byte
+	BlockStats     rlpOutputStatistics
+	ChainStats     rlpOutputStatistics
+	GasSummary     rlpGasAccountingSummary
+	Header         uint8
+	PreviousHeight []byte
+}
+
+// EncodeRLP implements rlp.Encoder.
+func (b *BlockInfo) EncodeRLP(w io.Writer) error {
+	var header uint8
+	var previousHeight []byte
+	if b.PreviousHeight != nil {
+		header |= previousHeightPresent
+		previousHeight = b.PreviousHeight.Bytes()
+	}
+	return rlp.Encode(w, rlpBlockInfo{
+		BlockNum:       bigIntToRLP(b.BlockNum),
+		Timestamp:      bigIntToRLP(b.Timestamp),
+		GasLimit:       bigIntToRLP(b.GasLimit),
+		BlockStats:     toRLPOutputStatistics(b.BlockStats),
+		ChainStats:     toRLPOutputStatistics(b.ChainStats),
+		GasSummary:     toRLPGasAccountingSummary(b.GasSummary),
+		Header:         header,
+		PreviousHeight: previousHeight,
+	})
+}
+
+// DecodeRLP implements rlp.Decoder.
+func (b *BlockInfo) DecodeRLP(s *rlp.Stream) error {
+	var dec rlpBlockInfo
+	if err := s.Decode(&dec); err != nil {
+		return err
+	}
+	b.BlockNum = rlpToBigInt(dec.BlockNum)
+	b.Timestamp = rlpToBigInt(dec.Timestamp)
+	b.GasLimit = rlpToBigInt(dec.GasLimit)
+	b.BlockStats = fromRLPOutputStatistics(dec.BlockStats)
+	b.ChainStats = fromRLPOutputStatistics(dec.ChainStats)
+	b.GasSummary = fromRLPGasAccountingSummary(dec.GasSummary)
+	if dec.Header&previousHeightPresent != 0 {
+		b.PreviousHeight = rlpToBigInt(dec.PreviousHeight)
+	} else {
+		b.PreviousHeight = nil
+	}
+	return nil
+}
+
+// EncodeBlockInfo RLP-encodes a BlockInfo for storage or transport.
+func EncodeBlockInfo(block *BlockInfo) ([]byte, error) {
+	return rlp.EncodeToBytes(block)
+}
+
+// DecodeBlockInfo is the inverse of EncodeBlockInfo.
+func DecodeBlockInfo(data []byte) (*BlockInfo, error) {
+	block := &BlockInfo{}
+	if err := rlp.DecodeBytes(data, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2019-2020, Offchain Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// receiptFromTxResult is exercised directly with a hand-built TxResult
+// rather than a raw AVM log value: the tuple parser NewResultFromValue
+// decodes logs into a TxResult with isn't in this tree (unlike
+// parseBlockResult, which is), so there's no way to fabricate a log value
+// it would accept.
+func TestReceiptFromTxResultSuccess(t *testing.T) {
+	block := &BlockInfo{BlockNum: big.NewInt(42)}
+	blockHash := common.HexToHash("0x1234")
+	to := common.HexToAddress("0xaaaa")
+
+	txRes := &TxResult{
+		TxType:            1,
+		CumulativeGasUsed: big.NewInt(21000),
+		GasUsed:           big.NewInt(21000),
+		TxHash:            common.HexToHash("0xbeef"),
+		ResultCode:        0,
+		To:                &to,
+		EVMLogs: []*types.Log{
+			{
+				Address: common.HexToAddress("0xbbbb"),
+				Topics:  []common.Hash{common.HexToHash("0xcccc")},
+				Data:    []byte{1, 2, 3},
+			},
+		},
+	}
+
+	receipt, err := receiptFromTxResult(block, blockHash, 3, txRes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Error("expected successful status for ResultCode 0")
+	}
+	if receipt.ContractAddress != (common.Address{}) {
+		t.Error("expected no contract address when To is set")
+	}
+	if receipt.TransactionIndex != 3 {
+		t.Error("expected receipt transaction index to match txIndex")
+	}
+	if receipt.BlockHash != blockHash || receipt.BlockNumber.Cmp(block.BlockNum) != 0 {
+		t.Error("expected receipt block fields to match the block")
+	}
+	if len(receipt.Logs) != 1 {
+		t.Fatal("expected the tx result's log to carry through")
+	}
+	log := receipt.Logs[0]
+	if log.BlockHash != blockHash || log.BlockNumber != block.BlockNum.Uint64() {
+		t.Error("expected per-log block fields to be stamped in")
+	}
+	if log.TxHash != txRes.TxHash || log.TxIndex != 3 {
+		t.Error("expected per-log tx fields to be stamped in")
+	}
+	wantBloom := types.CreateBloom(types.Receipts{receipt})
+	if receipt.Bloom != wantBloom {
+		t.Error("expected bloom to be recomputed from the receipt's logs")
+	}
+}
+
+func TestReceiptFromTxResultFailureAndContractCreation(t *testing.T) {
+	block := &BlockInfo{BlockNum: big.NewInt(1)}
+	blockHash := common.HexToHash("0x5678")
+	from := common.HexToAddress("0xdddd")
+
+	txRes := &TxResult{
+		TxType:            0,
+		CumulativeGasUsed: big.NewInt(100000),
+		GasUsed:           big.NewInt(50000),
+		TxHash:            common.HexToHash("0xf00d"),
+		ResultCode:        1,
+		To:                nil,
+		From:              from,
+		Nonce:             7,
+	}
+
+	receipt, err := receiptFromTxResult(block, blockHash, 0, txRes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if receipt.Status != types.ReceiptStatusFailed {
+		t.Error("expected failed status for a non-zero ResultCode")
+	}
+	wantContractAddress := crypto.CreateAddress(from, txRes.Nonce)
+	if receipt.ContractAddress != wantContractAddress {
+		t.Error("expected contract address derived from From/Nonce when To is nil")
+	}
+}
@@ -0,0 +1,44 @@
+/*
+* Copyright 2019-2020, Offchain Labs, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package validator
+
+import (
+	"math/big"
+
+	"github.com/offchainlabs/arbitrum/packages/arb-avm-cpp/cmachine"
+	"github.com/offchainlabs/arbitrum/packages/arb-util/common"
+)
+
+// ArbCoreLookup implements ValidatorLookup against a live cmachine.ArbCore,
+// deriving the log accumulator and outbound messages for a given range
+// directly from the local AVM replay. Validator.resolveNextNode does not
+// call it: confirming a valid node returns an error instead.
+type ArbCoreLookup struct {
+	arbCore cmachine.ArbCore
+}
+
+func NewArbCoreLookup(arbCore cmachine.ArbCore) *ArbCoreLookup {
+	return &ArbCoreLookup{arbCore: arbCore}
+}
+
+func (l *ArbCoreLookup) GenerateLogAccumulator(startIndex *big.Int, count *big.Int) (common.Hash, error) {
+	return cmachine.GenerateLogAccumulator(l.arbCore, startIndex, count)
+}
+
+func (l *ArbCoreLookup) GenerateSendsData(startIndex *big.Int, count *big.Int) ([][]byte, error) {
+	return cmachine.GenerateSendsData(l.arbCore, startIndex, count)
+}
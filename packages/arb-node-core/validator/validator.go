@@ -6,11 +6,13 @@ import (
 	"github.com/offchainlabs/arbitrum/packages/arb-node-core/ethbridge"
 	"github.com/offchainlabs/arbitrum/packages/arb-node-core/ethutils"
 	"github.com/offchainlabs/arbitrum/packages/arb-util/common"
+	"github.com/pkg/errors"
 	"math/big"
 )
 
 type ValidatorLookup interface {
 	GenerateLogAccumulator(startIndex *big.Int, count *big.Int) (common.Hash, error)
+	GenerateSendsData(startIndex *big.Int, count *big.Int) ([][]byte, error)
 }
 
 type Validator struct {
@@ -42,10 +44,16 @@ func (v *Validator) resolveNextNode(ctx context.Context) (*types.Transaction, er
 	case ethbridge.CONFIRM_TYPE_INVALID:
 		return v.rollup.RejectNextNode(ctx, successorWithStake, stakerAddress)
 	case ethbridge.CONFIRM_TYPE_VALID:
-		//logAcc, err := v.lookup.GenerateLogAccumulator()
-		var logAcc common.Hash
-		var messages [][]byte
-		return v.rollup.ConfirmNextNode(ctx, logAcc, messages)
+		// Confirming requires both the log accumulator and the raw sends
+		// successorWithStake's assertion produced. The log accumulator is
+		// cumulative by construction, so it could be regenerated from the
+		// tip alone, but the sends ConfirmNextNode expects are only the
+		// ones produced since the previously confirmed node, and this tree
+		// has no accessor for the per-node send range successorWithStake
+		// covers. Submitting a confirmation with an empty send list would
+		// desync from what the rollup contract expects and fail on-chain,
+		// so refuse instead of guessing at undefined Rollup/NodeInfo fields.
+		return nil, errors.New("confirming a valid node requires a per-node send range accessor not available in this tree")
 	default:
 		return nil, nil
 	}